@@ -0,0 +1,186 @@
+package helix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type batchItem struct {
+	name     string
+	endpoint string
+	opts     []QueryOptionFunc
+}
+
+type batchConfig struct {
+	maxConcurrency int
+}
+
+type BatchOptionFunc func(*batchConfig)
+
+// WithMaxConcurrency bounds how many queued queries a BatchRequest runs at
+// once. Defaults to 1 (sequential) when not set.
+func WithMaxConcurrency(n int) BatchOptionFunc {
+	return func(c *batchConfig) {
+		c.maxConcurrency = n
+	}
+}
+
+// BatchRequest queues several queries to be executed together with bounded
+// concurrency, since HelixDB has no native batch endpoint.
+type BatchRequest struct {
+	client *Client
+	config batchConfig
+	items  []batchItem
+}
+
+// Batch returns a BatchRequest bound to c.
+func (c *Client) Batch(opts ...BatchOptionFunc) *BatchRequest {
+	config := batchConfig{maxConcurrency: 1}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &BatchRequest{
+		client: c,
+		config: config,
+	}
+}
+
+// Add queues a query under name, so its result can later be scanned via
+// WithDest(name, ...).
+func (b *BatchRequest) Add(name string, endpoint string, opts ...QueryOptionFunc) *BatchRequest {
+	b.items = append(b.items, batchItem{
+		name:     name,
+		endpoint: endpoint,
+		opts:     opts,
+	})
+
+	return b
+}
+
+// BatchResponse holds the raw result (or error) of every query queued on a
+// BatchRequest, keyed by the name it was Add-ed under.
+type BatchResponse struct {
+	results map[string]json.RawMessage
+	errs    map[string]error
+}
+
+// Do executes every queued query, running up to WithMaxConcurrency queries
+// at a time.
+func (b *BatchRequest) Do(ctx context.Context) (*BatchResponse, error) {
+	maxConcurrency := b.config.maxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	res := &BatchResponse{
+		results: make(map[string]json.RawMessage, len(b.items)),
+		errs:    make(map[string]error),
+	}
+
+	for _, item := range b.items {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(item batchItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			body, err := b.client.QueryContext(ctx, item.endpoint, item.opts...).Raw()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				res.errs[item.name] = err
+				return
+			}
+			res.results[item.name] = unwrapSingleField(body)
+		}(item)
+	}
+
+	wg.Wait()
+
+	if len(res.errs) > 0 {
+		return res, fmt.Errorf("batch: %d of %d queries failed", len(res.errs), len(b.items))
+	}
+
+	return res, nil
+}
+
+// unwrapSingleField returns the value of body's sole top-level JSON field.
+// Every HelixQL query response is a single-field wrapper object (e.g.
+// {"followers": [...]}), but a batch item's name is chosen by the caller to
+// be unique across the batch (e.g. "followers:alice"), not to match that
+// field, so the wrapper can't be unwrapped by looking it up by name the way
+// a single Query's Scan does. If body isn't a single-field object, it's
+// returned unchanged.
+func unwrapSingleField(body []byte) json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || len(fields) != 1 {
+		return json.RawMessage(body)
+	}
+
+	for _, v := range fields {
+		return v
+	}
+	return json.RawMessage(body)
+}
+
+// DoAndScan executes every queued query and scans each result into the
+// destination named by its key in dests.
+func (b *BatchRequest) DoAndScan(ctx context.Context, dests map[string]any) error {
+	res, err := b.Do(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := make([]ScanOptionFunc, 0, len(dests))
+	for name, dest := range dests {
+		args = append(args, WithDest(name, dest))
+	}
+
+	return res.Scan(args...)
+}
+
+// Scan dispatches queued query results to destinations named via WithDest,
+// where name is the item name a query was Add-ed under. Unlike
+// (*helixResponse).Scan, each item's result is unwrapped and decoded on its
+// own (see unwrapSingleField), since batch item names are caller-chosen to
+// be unique and don't correspond to a shared field map the way a single
+// query's response fields do.
+func (r *BatchResponse) Scan(args ...ScanOptionFunc) error {
+	for _, arg := range args {
+		var opt ScanOption
+		arg(&opt)
+
+		if err := validateDestPointer(opt.dest); err != nil {
+			return err
+		}
+
+		if batchErr, ok := r.errs[opt.name]; ok {
+			return fmt.Errorf("query %q failed: %w", opt.name, batchErr)
+		}
+	}
+
+	for _, arg := range args {
+		var opt ScanOption
+		arg(&opt)
+
+		raw, ok := r.results[opt.name]
+		if !ok {
+			return &DecodeError{Endpoint: opt.name, Err: fmt.Errorf("no result for %q", opt.name)}
+		}
+
+		if err := json.Unmarshal(raw, opt.dest); err != nil {
+			return &DecodeError{Endpoint: opt.name, Err: err}
+		}
+	}
+
+	return nil
+}
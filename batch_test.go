@@ -0,0 +1,121 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestBatchScanUnwrapsPerItemField is a regression test for a bug where
+// BatchResponse.Scan tried to look up each item's destination by batch item
+// name inside the *other* items' raw bodies (via the single-query
+// scanOption field map), instead of unwrapping each item's own response.
+// That broke the exact shape this series' example/main.go demo uses: one
+// endpoint ("followers") queried for several users under distinct,
+// per-user batch item names.
+func TestBatchScanUnwrapsPerItemField(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"followers":[{"Name":"alice"}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL + "/")
+	b := c.Batch(WithMaxConcurrency(2))
+	b.Add("followers:bob", "followers", WithData(map[string]any{"id": "bob"}))
+	b.Add("followers:carol", "followers", WithData(map[string]any{"id": "carol"}))
+
+	res, err := b.Do(context.Background())
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	var bobFollowers, carolFollowers []struct{ Name string }
+	err = res.Scan(
+		WithDest("followers:bob", &bobFollowers),
+		WithDest("followers:carol", &carolFollowers),
+	)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if len(bobFollowers) != 1 || bobFollowers[0].Name != "alice" {
+		t.Errorf("bobFollowers = %+v, want [{alice}]", bobFollowers)
+	}
+	if len(carolFollowers) != 1 || carolFollowers[0].Name != "alice" {
+		t.Errorf("carolFollowers = %+v, want [{alice}]", carolFollowers)
+	}
+}
+
+func TestBatchDoRespectsMaxConcurrency(t *testing.T) {
+	const concurrency = 2
+	var inFlight, maxSeen int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+			if cur <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, cur) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"thing":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL + "/")
+	b := c.Batch(WithMaxConcurrency(concurrency))
+	for i := 0; i < 6; i++ {
+		b.Add(string(rune('a'+i)), "thing", WithData(map[string]any{}))
+	}
+
+	if _, err := b.Do(context.Background()); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&maxSeen); got > concurrency {
+		t.Errorf("max concurrent requests = %d, want <= %d", got, concurrency)
+	}
+}
+
+func TestBatchDoCollectsErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"thing":"ok"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL + "/")
+	b := c.Batch(WithMaxConcurrency(2))
+	b.Add("good", "thing", WithData(map[string]any{}))
+	b.Add("bad", "fail", WithData(map[string]any{}))
+
+	res, err := b.Do(context.Background())
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var dest string
+	if err := res.Scan(WithDest("bad", &dest)); err == nil {
+		t.Error("Scan on a failed item should return its error, got nil")
+	}
+	if err := res.Scan(WithDest("good", &dest)); err != nil {
+		t.Errorf("Scan on a succeeded item: %v", err)
+	}
+	if dest != "ok" {
+		t.Errorf("dest = %q, want %q", dest, "ok")
+	}
+}
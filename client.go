@@ -0,0 +1,134 @@
+package helix
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Client is a thin wrapper around an http.Client configured to talk to a
+// HelixDB instance at host.
+type Client struct {
+	host       string
+	httpClient *http.Client
+
+	authHeader string
+	authValue  string
+
+	requestMiddleware  []func(*http.Request) error
+	responseMiddleware []func(*http.Response) error
+
+	retry *retryPolicy
+}
+
+type ClientOption func(*Client)
+
+// NewClient returns a Client that sends queries to host, configured by opts.
+func NewClient(host string, opts ...ClientOption) *Client {
+	c := &Client{
+		host:       host,
+		httpClient: &http.Client{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithBearerToken authenticates every query with an `Authorization: Bearer
+// <token>` header.
+func WithBearerToken(token string) ClientOption {
+	return func(c *Client) {
+		c.authHeader = "Authorization"
+		c.authValue = "Bearer " + token
+	}
+}
+
+// WithAPIKey authenticates every query by setting header to value.
+func WithAPIKey(header, value string) ClientOption {
+	return func(c *Client) {
+		c.authHeader = header
+		c.authValue = value
+	}
+}
+
+// WithRequestMiddleware registers fn to run against every outgoing request,
+// in the order middleware is added, before the request is sent.
+func WithRequestMiddleware(fn func(*http.Request) error) ClientOption {
+	return func(c *Client) {
+		c.requestMiddleware = append(c.requestMiddleware, fn)
+	}
+}
+
+// WithResponseMiddleware registers fn to run against every response, in the
+// order middleware is added, before its body is read.
+func WithResponseMiddleware(fn func(*http.Response) error) ClientOption {
+	return func(c *Client) {
+		c.responseMiddleware = append(c.responseMiddleware, fn)
+	}
+}
+
+// BackoffFunc returns how long to wait before the given retry attempt
+// (1-indexed: attempt 1 is the delay before the first retry).
+type BackoffFunc func(attempt int) time.Duration
+
+type retryPolicy struct {
+	maxAttempts int
+	backoff     BackoffFunc
+}
+
+// WithRetry retries queries that fail with a 429 or 5xx response, waiting
+// backoff(attempt) between attempts, up to maxAttempts total tries. Only
+// queries made with WithIdempotencyKey are actually retried (see
+// QueryContext), since retrying a write without one risks applying it
+// twice. maxAttempts is clamped to at least 1 and backoff defaults to
+// ExponentialBackoff(100ms) if nil.
+func WithRetry(maxAttempts int, backoff BackoffFunc) ClientOption {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	if backoff == nil {
+		backoff = ExponentialBackoff(100 * time.Millisecond)
+	}
+
+	return func(c *Client) {
+		c.retry = &retryPolicy{
+			maxAttempts: maxAttempts,
+			backoff:     backoff,
+		}
+	}
+}
+
+// maxBackoffDelay caps the delay ExponentialBackoff computes before jitter,
+// both so callers don't end up waiting unreasonably long between retries
+// and so the doubling can't overflow time.Duration on a high attempt count.
+const maxBackoffDelay = 2 * time.Minute
+
+// ExponentialBackoff returns a BackoffFunc that doubles base on every
+// attempt, capped at maxBackoffDelay, and adds random jitter of up to half
+// the computed delay.
+func ExponentialBackoff(base time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		d := base
+
+		// Cap the shift itself, not just its result: base<<shift can wrap
+		// around to a negative number before shift reaches 63, and a huge
+		// shift count is itself meaningless once d would already exceed
+		// maxBackoffDelay.
+		if shift := attempt - 1; shift > 0 {
+			if shift > 32 {
+				shift = 32
+			}
+			d = base << shift
+		}
+
+		if d <= 0 || d > maxBackoffDelay {
+			d = maxBackoffDelay
+		}
+
+		jitter := time.Duration(rand.Int63n(int64(d)/2 + 1))
+		return d + jitter
+	}
+}
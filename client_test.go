@@ -0,0 +1,150 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithBearerTokenSetsAuthorizationHeader(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/", WithBearerToken("tok-123"))
+
+	_, err := c.QueryContext(context.Background(), "thing", WithData(map[string]any{})).Raw()
+	if err != nil {
+		t.Fatalf("Raw: %v", err)
+	}
+
+	if want := "Bearer tok-123"; gotAuth != want {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, want)
+	}
+}
+
+func TestWithAPIKeySetsConfiguredHeader(t *testing.T) {
+	var gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("X-Api-Key")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/", WithAPIKey("X-Api-Key", "secret"))
+
+	_, err := c.QueryContext(context.Background(), "thing", WithData(map[string]any{})).Raw()
+	if err != nil {
+		t.Fatalf("Raw: %v", err)
+	}
+
+	if want := "secret"; gotKey != want {
+		t.Errorf("X-Api-Key header = %q, want %q", gotKey, want)
+	}
+}
+
+func TestRequestMiddlewareRunsInRegistrationOrder(t *testing.T) {
+	var gotHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Order")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	c := NewClient(srv.URL+"/",
+		WithRequestMiddleware(func(req *http.Request) error {
+			order = append(order, "first")
+			req.Header.Set("X-Order", "first")
+			return nil
+		}),
+		WithRequestMiddleware(func(req *http.Request) error {
+			order = append(order, "second")
+			req.Header.Set("X-Order", "second")
+			return nil
+		}),
+	)
+
+	_, err := c.QueryContext(context.Background(), "thing", WithData(map[string]any{})).Raw()
+	if err != nil {
+		t.Fatalf("Raw: %v", err)
+	}
+
+	if want := []string{"first", "second"}; !equalStrings(order, want) {
+		t.Errorf("middleware order = %v, want %v", order, want)
+	}
+	if want := "second"; gotHeader != want {
+		t.Errorf("request saw X-Order = %q, want %q (last middleware wins)", gotHeader, want)
+	}
+}
+
+func TestRequestMiddlewareErrorAbortsCall(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	wantErr := errors.New("boom")
+	c := NewClient(srv.URL+"/", WithRequestMiddleware(func(req *http.Request) error {
+		return wantErr
+	}))
+
+	_, err := c.QueryContext(context.Background(), "thing", WithData(map[string]any{})).Raw()
+	if err == nil {
+		t.Fatal("expected an error from a failing request middleware, got nil")
+	}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want one wrapping %v", err, wantErr)
+	}
+	if called {
+		t.Error("server was called despite request middleware rejecting the request")
+	}
+}
+
+func TestResponseMiddlewareRunsAndCanAbort(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	var gotStatus int
+	wantErr := errors.New("rejected")
+	c := NewClient(srv.URL+"/", WithResponseMiddleware(func(res *http.Response) error {
+		gotStatus = res.StatusCode
+		return wantErr
+	}))
+
+	_, err := c.QueryContext(context.Background(), "thing", WithData(map[string]any{})).Raw()
+	if err == nil {
+		t.Fatal("expected an error from a failing response middleware, got nil")
+	}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want one wrapping %v", err, wantErr)
+	}
+	if gotStatus != http.StatusOK {
+		t.Errorf("response middleware saw status %d, want %d", gotStatus, http.StatusOK)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
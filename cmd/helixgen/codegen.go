@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"text/template"
+)
+
+var codeTemplate = template.Must(template.New("gen").Funcs(template.FuncMap{
+	"field": func(f Field) string {
+		if f.Tag == "" {
+			return fmt.Sprintf("%s %s", f.Name, f.Type)
+		}
+		return fmt.Sprintf("%s %s %s", f.Name, f.Type, f.Tag)
+	},
+}).Parse(`// Code generated by helixgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+
+	helix "github.com/HelixDB/helix-go"
+)
+
+// Client wraps a *helix.Client with the typed queries generated from the
+// HelixDB schema.
+type Client struct {
+	*helix.Client
+}
+
+// New returns a Client that dispatches generated queries through c.
+func New(c *helix.Client) *Client {
+	return &Client{Client: c}
+}
+{{range .Models}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{field .}}
+{{- end}}
+}
+{{end}}
+{{range .Queries}}
+{{- if .Input}}
+type {{.Name}}Input struct {
+{{- range .Input}}
+	{{field .}}
+{{- end}}
+}
+{{end}}
+{{- if .OutputType}}
+func (c *Client) {{.Name}}(ctx context.Context{{if .Input}}, in {{.Name}}Input{{end}}) ({{.OutputType}}, error) {
+	var out {{.OutputType}}
+	err := c.QueryContext(ctx, "{{.Endpoint}}"{{if .Input}}, helix.WithData(in){{end}}).Scan(
+		helix.WithDest("{{.OutputName}}", &out),
+	)
+	if err != nil {
+		return out, fmt.Errorf("{{.Name}}: %w", err)
+	}
+	return out, nil
+}
+{{else}}
+func (c *Client) {{.Name}}(ctx context.Context{{if .Input}}, in {{.Name}}Input{{end}}) error {
+	_, err := c.QueryContext(ctx, "{{.Endpoint}}"{{if .Input}}, helix.WithData(in){{end}}).Raw()
+	if err != nil {
+		return fmt.Errorf("{{.Name}}: %w", err)
+	}
+	return nil
+}
+{{end}}
+{{end}}
+`))
+
+func generate(schema *Schema, pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	err := codeTemplate.Execute(&buf, struct {
+		Package string
+		Models  []Model
+		Queries []Query
+	}{
+		Package: pkg,
+		Models:  schema.Models,
+		Queries: schema.Queries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("executing template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	return formatted, nil
+}
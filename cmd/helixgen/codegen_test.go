@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	schema, err := parseSchema(strings.NewReader(testSchema))
+	if err != nil {
+		t.Fatalf("parseSchema: %v", err)
+	}
+
+	src, err := generate(schema, "genpkg")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	out := string(src)
+
+	if !strings.HasPrefix(out, "// Code generated by helixgen. DO NOT EDIT.") {
+		t.Errorf("missing generated-code header:\n%s", out)
+	}
+	if !strings.Contains(out, "package genpkg") {
+		t.Errorf("missing package clause:\n%s", out)
+	}
+
+	wantSnippets := []string{
+		"type User struct",
+		"CreatedAt int32 `json:\"created_at\"`",
+		"type FollowInput struct",
+		"func (c *Client) Follow(ctx context.Context, in FollowInput) error {",
+		`c.QueryContext(ctx, "follow", helix.WithData(in)).Raw()`,
+		"func (c *Client) Followers(ctx context.Context, in FollowersInput) ([]User, error) {",
+		`helix.WithDest("followers", &out)`,
+		"func (c *Client) GetUsers(ctx context.Context) ([]User, error) {",
+	}
+	for _, snippet := range wantSnippets {
+		if !strings.Contains(out, snippet) {
+			t.Errorf("generated code missing %q:\n%s", snippet, out)
+		}
+	}
+
+	// GetUsers takes no input, so it must not declare a GetUsersInput type.
+	if strings.Contains(out, "GetUsersInput") {
+		t.Errorf("generated code should not declare GetUsersInput:\n%s", out)
+	}
+}
+
+func TestGenerateEmptySchema(t *testing.T) {
+	src, err := generate(&Schema{}, "genpkg")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !strings.Contains(string(src), "package genpkg") {
+		t.Errorf("missing package clause:\n%s", src)
+	}
+}
@@ -0,0 +1,63 @@
+// Command helixgen reads a HelixDB schema/query definition file and emits
+// typed query wrappers and model structs that call the existing
+// Query/Scan/WithData/WithDest plumbing in github.com/HelixDB/helix-go.
+//
+// Typical usage, driven by a //go:generate directive:
+//
+//	//go:generate helixgen -schema ./queries.hx -out ./internal/gen
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "helixgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("helixgen", flag.ContinueOnError)
+	schemaPath := fs.String("schema", "", "path to the HelixDB schema/query definition file")
+	outDir := fs.String("out", "", "directory to write the generated package into")
+	pkg := fs.String("package", "gen", "package name for the generated code")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *schemaPath == "" || *outDir == "" {
+		return fmt.Errorf("both -schema and -out are required")
+	}
+
+	f, err := os.Open(*schemaPath)
+	if err != nil {
+		return fmt.Errorf("opening schema: %w", err)
+	}
+	defer f.Close()
+
+	schema, err := parseSchema(f)
+	if err != nil {
+		return fmt.Errorf("parsing schema: %w", err)
+	}
+
+	code, err := generate(schema, *pkg)
+	if err != nil {
+		return fmt.Errorf("generating code: %w", err)
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	outPath := filepath.Join(*outDir, "gen.go")
+	if err := os.WriteFile(outPath, code, 0o644); err != nil {
+		return fmt.Errorf("writing generated code: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package main
+
+// Parses the small schema format helixgen reads, e.g.:
+//
+//	model User {
+//	    ID string
+//	    Name string
+//	    CreatedAt int32 `json:"created_at"`
+//	}
+//
+//	query Followers /followers {
+//	    input {
+//	        ID string `json:"id"`
+//	    }
+//	    output followers []User
+//	}
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Field struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+type Model struct {
+	Name   string
+	Fields []Field
+}
+
+type Query struct {
+	Name       string
+	Endpoint   string
+	Input      []Field
+	OutputName string
+	OutputType string
+}
+
+type Schema struct {
+	Models  []Model
+	Queries []Query
+}
+
+func parseSchema(r io.Reader) (*Schema, error) {
+	scanner := bufio.NewScanner(r)
+
+	var schema Schema
+	lineNo := 0
+
+	nextLine := func() (string, bool) {
+		for scanner.Scan() {
+			lineNo++
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "//") {
+				continue
+			}
+			return line, true
+		}
+		return "", false
+	}
+
+	for {
+		line, ok := nextLine()
+		if !ok {
+			break
+		}
+
+		switch {
+		case strings.HasPrefix(line, "model "):
+			model, err := parseModel(line, nextLine)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			schema.Models = append(schema.Models, *model)
+
+		case strings.HasPrefix(line, "query "):
+			query, err := parseQuery(line, nextLine)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			schema.Queries = append(schema.Queries, *query)
+
+		default:
+			return nil, fmt.Errorf("line %d: unexpected %q", lineNo, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+func parseModel(header string, nextLine func() (string, bool)) (*Model, error) {
+	header = strings.TrimSuffix(header, "{")
+	name := strings.TrimSpace(strings.TrimPrefix(header, "model"))
+	if name == "" {
+		return nil, fmt.Errorf("model missing a name")
+	}
+
+	model := &Model{Name: name}
+
+	for {
+		line, ok := nextLine()
+		if !ok {
+			return nil, fmt.Errorf("model %q: unexpected end of file", name)
+		}
+		if line == "}" {
+			return model, nil
+		}
+
+		field, err := parseField(line)
+		if err != nil {
+			return nil, fmt.Errorf("model %q: %w", name, err)
+		}
+		model.Fields = append(model.Fields, *field)
+	}
+}
+
+func parseQuery(header string, nextLine func() (string, bool)) (*Query, error) {
+	header = strings.TrimSuffix(header, "{")
+	parts := strings.Fields(strings.TrimPrefix(header, "query"))
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected \"query <Name> <endpoint> {\"")
+	}
+	query := &Query{Name: parts[0], Endpoint: parts[1]}
+
+	for {
+		line, ok := nextLine()
+		if !ok {
+			return nil, fmt.Errorf("query %q: unexpected end of file", query.Name)
+		}
+
+		switch {
+		case line == "}":
+			return query, nil
+
+		case line == "input {":
+			for {
+				fieldLine, ok := nextLine()
+				if !ok {
+					return nil, fmt.Errorf("query %q: unexpected end of file", query.Name)
+				}
+				if fieldLine == "}" {
+					break
+				}
+				field, err := parseField(fieldLine)
+				if err != nil {
+					return nil, fmt.Errorf("query %q: %w", query.Name, err)
+				}
+				query.Input = append(query.Input, *field)
+			}
+
+		case strings.HasPrefix(line, "output "):
+			outParts := strings.Fields(strings.TrimPrefix(line, "output"))
+			if len(outParts) != 2 {
+				return nil, fmt.Errorf("query %q: expected \"output <field> <Type>\"", query.Name)
+			}
+			query.OutputName = outParts[0]
+			query.OutputType = outParts[1]
+
+		default:
+			return nil, fmt.Errorf("query %q: unexpected %q", query.Name, line)
+		}
+	}
+}
+
+// parseField parses "Name type" optionally followed by a backtick struct tag.
+func parseField(line string) (*Field, error) {
+	rest := line
+	tag := ""
+	if idx := strings.Index(rest, "`"); idx != -1 {
+		tag = strings.TrimSpace(rest[idx:])
+		rest = strings.TrimSpace(rest[:idx])
+	}
+
+	parts := strings.Fields(rest)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected \"Name type\", got %q", line)
+	}
+
+	return &Field{Name: parts[0], Type: parts[1], Tag: tag}, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const testSchema = `
+model User {
+    ID string
+    Name string
+    CreatedAt int32 ` + "`json:\"created_at\"`" + `
+}
+
+query Follow follow {
+    input {
+        FollowerId string ` + "`json:\"followerId\"`" + `
+        FollowedId string ` + "`json:\"followedId\"`" + `
+    }
+}
+
+query Followers followers {
+    input {
+        ID string ` + "`json:\"id\"`" + `
+    }
+    output followers []User
+}
+
+query GetUsers get_users {
+    output users []User
+}
+`
+
+func TestParseSchema(t *testing.T) {
+	schema, err := parseSchema(strings.NewReader(testSchema))
+	if err != nil {
+		t.Fatalf("parseSchema: %v", err)
+	}
+
+	if len(schema.Models) != 1 {
+		t.Fatalf("got %d models, want 1", len(schema.Models))
+	}
+	user := schema.Models[0]
+	if user.Name != "User" {
+		t.Errorf("model name = %q, want User", user.Name)
+	}
+	if len(user.Fields) != 3 {
+		t.Fatalf("got %d fields, want 3", len(user.Fields))
+	}
+	if got, want := user.Fields[2].Tag, "`json:\"created_at\"`"; got != want {
+		t.Errorf("CreatedAt tag = %q, want %q", got, want)
+	}
+
+	if len(schema.Queries) != 3 {
+		t.Fatalf("got %d queries, want 3", len(schema.Queries))
+	}
+
+	follow := schema.Queries[0]
+	if follow.Name != "Follow" || follow.Endpoint != "follow" {
+		t.Errorf("Follow query = %+v", follow)
+	}
+	if len(follow.Input) != 2 || follow.OutputType != "" {
+		t.Errorf("Follow should have 2 input fields and no output, got %+v", follow)
+	}
+
+	followers := schema.Queries[1]
+	if followers.OutputName != "followers" || followers.OutputType != "[]User" {
+		t.Errorf("Followers output = %q %q, want followers []User", followers.OutputName, followers.OutputType)
+	}
+
+	getUsers := schema.Queries[2]
+	if len(getUsers.Input) != 0 {
+		t.Errorf("GetUsers should have no input fields, got %+v", getUsers.Input)
+	}
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+	cases := []string{
+		"model User {\n  ID\n}\n",             // field missing a type
+		"query Follow {\n}\n",                 // query missing an endpoint
+		"model User {\n",                      // unterminated block
+		"not a valid top-level declaration\n", // unrecognized line
+	}
+
+	for _, src := range cases {
+		if _, err := parseSchema(strings.NewReader(src)); err == nil {
+			t.Errorf("parseSchema(%q): expected an error, got nil", src)
+		}
+	}
+}
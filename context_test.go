@@ -0,0 +1,77 @@
+package helix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestQueryContextCanceledBeforeResponse(t *testing.T) {
+	block := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	c := NewClient(srv.URL + "/")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := c.QueryContext(ctx, "thing", WithData(map[string]any{})).Raw()
+	if err == nil {
+		t.Fatal("expected an error from a canceled context, got nil")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want one wrapping context.Canceled", err)
+	}
+}
+
+func TestQueryContextWithTimeoutExpires(t *testing.T) {
+	block := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	c := NewClient(srv.URL + "/")
+
+	_, err := c.QueryContext(context.Background(), "thing",
+		WithData(map[string]any{}),
+		WithTimeout(20*time.Millisecond),
+	).Raw()
+	if err == nil {
+		t.Fatal("expected an error from an expired deadline, got nil")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want one wrapping context.DeadlineExceeded", err)
+	}
+}
+
+func TestQueryContextSucceedsBeforeTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL + "/")
+
+	_, err := c.QueryContext(context.Background(), "thing",
+		WithData(map[string]any{}),
+		WithTimeout(time.Second),
+	).Raw()
+	if err != nil {
+		t.Fatalf("Raw: %v", err)
+	}
+}
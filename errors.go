@@ -0,0 +1,112 @@
+package helix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// TransportError wraps a failure to send a request or read a response,
+// as opposed to the server returning an error response.
+type TransportError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("transport error calling %q: %s", e.Endpoint, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPError is returned when the server responds with a non-2xx status
+// that could not be parsed into a QueryError.
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+	Endpoint   string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%q: %d: %s", e.Endpoint, e.StatusCode, e.Body)
+}
+
+// DecodeError wraps a failure to decode a response body into the
+// requested destination.
+type DecodeError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding response from %q: %s", e.Endpoint, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// QueryError is a server-side query error, parsed from HelixDB's JSON
+// error envelope: {"error": {"code": "...", "message": "...", "field": "..."}}.
+type QueryError struct {
+	Endpoint   string
+	StatusCode int
+	Code       string
+	Message    string
+	Field      string
+}
+
+func (e *QueryError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("%q: %s (field %q): %s", e.Endpoint, e.Code, e.Field, e.Message)
+	}
+	return fmt.Sprintf("%q: %s: %s", e.Endpoint, e.Code, e.Message)
+}
+
+type queryErrorEnvelope struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Field   string `json:"field"`
+	} `json:"error"`
+}
+
+// parseQueryError attempts to parse HelixDB's JSON error envelope out of
+// body. It returns nil if body doesn't look like one.
+func parseQueryError(endpoint string, statusCode int, body []byte) *QueryError {
+	var env queryErrorEnvelope
+	if err := json.Unmarshal(body, &env); err != nil || env.Error.Message == "" {
+		return nil
+	}
+
+	return &QueryError{
+		Endpoint:   endpoint,
+		StatusCode: statusCode,
+		Code:       env.Error.Code,
+		Message:    env.Error.Message,
+		Field:      env.Error.Field,
+	}
+}
+
+// IsRetryable reports whether err is the kind of error a caller can expect
+// to go away on retry: a transport failure or an HTTP 429/5xx response.
+func IsRetryable(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return isRetryableStatus(httpErr.StatusCode)
+	}
+
+	var queryErr *QueryError
+	if errors.As(err, &queryErr) {
+		return isRetryableStatus(queryErr.StatusCode)
+	}
+
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+package helix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTransportErrorIsRetryable(t *testing.T) {
+	err := &TransportError{Endpoint: "thing", Err: errors.New("dial failed")}
+
+	var got *TransportError
+	if !errors.As(err, &got) {
+		t.Fatal("errors.As into *TransportError failed")
+	}
+	if !IsRetryable(err) {
+		t.Error("IsRetryable(TransportError) = false, want true")
+	}
+}
+
+func TestHTTPErrorIsRetryable(t *testing.T) {
+	cases := []struct {
+		status int
+		want   bool
+	}{
+		{500, true},
+		{503, true},
+		{429, true},
+		{400, false},
+		{404, false},
+	}
+
+	for _, tc := range cases {
+		err := &HTTPError{StatusCode: tc.status, Endpoint: "thing", Body: []byte("boom")}
+
+		var got *HTTPError
+		if !errors.As(error(err), &got) {
+			t.Fatalf("status %d: errors.As into *HTTPError failed", tc.status)
+		}
+		if IsRetryable(err) != tc.want {
+			t.Errorf("status %d: IsRetryable = %v, want %v", tc.status, !tc.want, tc.want)
+		}
+	}
+}
+
+func TestQueryErrorIsRetryable(t *testing.T) {
+	cases := []struct {
+		status int
+		want   bool
+	}{
+		{502, true},
+		{429, true},
+		{422, false},
+	}
+
+	for _, tc := range cases {
+		err := &QueryError{Endpoint: "thing", StatusCode: tc.status, Code: "bad_input", Message: "nope"}
+
+		var got *QueryError
+		if !errors.As(error(err), &got) {
+			t.Fatalf("status %d: errors.As into *QueryError failed", tc.status)
+		}
+		if IsRetryable(err) != tc.want {
+			t.Errorf("status %d: IsRetryable = %v, want %v", tc.status, !tc.want, tc.want)
+		}
+	}
+}
+
+func TestDecodeErrorUnwraps(t *testing.T) {
+	inner := errors.New("unexpected end of JSON input")
+	err := &DecodeError{Endpoint: "thing", Err: inner}
+
+	if !errors.Is(err, inner) {
+		t.Error("errors.Is(DecodeError, inner) = false, want true")
+	}
+	if IsRetryable(err) {
+		t.Error("IsRetryable(DecodeError) = true, want false")
+	}
+}
+
+func TestParseQueryErrorPopulatesStatusCode(t *testing.T) {
+	body := []byte(`{"error":{"code":"rate_limited","message":"slow down","field":""}}`)
+
+	err := parseQueryError("thing", 429, body)
+	if err == nil {
+		t.Fatal("parseQueryError returned nil for a valid envelope")
+	}
+	if err.StatusCode != 429 {
+		t.Errorf("StatusCode = %d, want 429", err.StatusCode)
+	}
+	if !IsRetryable(err) {
+		t.Error("IsRetryable(parsed 429 QueryError) = false, want true")
+	}
+}
+
+func TestParseQueryErrorNonEnvelope(t *testing.T) {
+	if err := parseQueryError("thing", 500, []byte(`{"not":"an error envelope"}`)); err != nil {
+		t.Errorf("parseQueryError = %+v, want nil for a non-envelope body", err)
+	}
+}
@@ -0,0 +1,20 @@
+package internal
+
+// Contains Helix client configuration
+
+import (
+	"os"
+
+	helix "github.com/HelixDB/helix-go"
+)
+
+var HelixClient *helix.Client
+
+func ConfigHelix() {
+	host := os.Getenv("HELIX_HOST")
+	if host == "" {
+		host = "http://localhost:6969/"
+	}
+
+	HelixClient = helix.NewClient(host)
+}
@@ -0,0 +1,8 @@
+// Package internal contains hand-written HelixDB query wrappers for the
+// example app.
+//
+// Typed wrappers can also be generated straight from the schema with
+// helixgen instead of being hand-written like the ones in this package:
+//
+//go:generate go run github.com/HelixDB/helix-go/cmd/helixgen -schema ../queries.hx -out ./gen
+package internal
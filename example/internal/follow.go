@@ -3,6 +3,7 @@ package internal
 // Contains `Follow` related queries
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/HelixDB/helix-go"
@@ -14,12 +15,17 @@ type FollowUserInput struct {
 }
 
 func FollowUser(data *FollowUserInput) error {
-	_, err := HelixClient.Query(
+	return FollowUserContext(context.Background(), data)
+}
+
+func FollowUserContext(ctx context.Context, data *FollowUserInput) error {
+	_, err := HelixClient.QueryContext(
+		ctx,
 		"follow",
 		helix.WithData(data),
 	).Raw()
 	if err != nil {
-		err = fmt.Errorf("Error while following: %s", err)
+		err = fmt.Errorf("Error while following: %w", err)
 		return err
 	}
 
@@ -27,14 +33,19 @@ func FollowUser(data *FollowUserInput) error {
 }
 
 func Followers(data map[string]any, users *[]User) error {
-	err := HelixClient.Query(
+	return FollowersContext(context.Background(), data, users)
+}
+
+func FollowersContext(ctx context.Context, data map[string]any, users *[]User) error {
+	err := HelixClient.QueryContext(
+		ctx,
 		"followers",
 		helix.WithData(data),
 	).Scan(
 		helix.WithDest("followers", users),
 	)
 	if err != nil {
-		err = fmt.Errorf("Error while getting \"followers\": %s", err)
+		err = fmt.Errorf("Error while getting \"followers\": %w", err)
 		return err
 	}
 
@@ -42,14 +53,19 @@ func Followers(data map[string]any, users *[]User) error {
 }
 
 func Following(data map[string]any, users *[]User) error {
-	err := HelixClient.Query(
+	return FollowingContext(context.Background(), data, users)
+}
+
+func FollowingContext(ctx context.Context, data map[string]any, users *[]User) error {
+	err := HelixClient.QueryContext(
+		ctx,
 		"following",
 		helix.WithData(data),
 	).Scan(
 		helix.WithDest("following", users),
 	)
 	if err != nil {
-		err = fmt.Errorf("Error while getting \"following\": %s", err)
+		err = fmt.Errorf("Error while getting \"following\": %w", err)
 		return err
 	}
 
@@ -29,7 +29,7 @@ func CreateUser(newUser map[string]any, user *CreateUserResponse) error {
 		helix.WithData(newUser),
 	).Scan(user)
 	if err != nil {
-		err := fmt.Errorf("Error while creating user: %s", err)
+		err := fmt.Errorf("Error while creating user: %w", err)
 		return err
 	}
 
@@ -42,7 +42,7 @@ func CreateUsers(newUsers map[string]any) (map[string]any, error) {
 		helix.WithData(newUsers),
 	).AsMap()
 	if err != nil {
-		err = fmt.Errorf("Error while creating user: %s", err)
+		err = fmt.Errorf("Error while creating user: %w", err)
 		return nil, err
 	}
 
@@ -69,7 +69,7 @@ func DeleteUser(data map[string]any) error {
 		helix.WithData(data),
 	).Raw()
 	if err != nil {
-		err = fmt.Errorf("Error while deleting user: %s", err)
+		err = fmt.Errorf("Error while deleting user: %w", err)
 		return err
 	}
 
@@ -81,7 +81,7 @@ func GetAllUsers(users *[]User) error {
 		helix.WithDest("users", &users),
 	)
 	if err != nil {
-		err = fmt.Errorf("Error while getting users: %s", err)
+		err = fmt.Errorf("Error while getting users: %w", err)
 		return err
 	}
 
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
 	"example/internal"
+
+	helix "github.com/HelixDB/helix-go"
 )
 
 func main() {
@@ -73,71 +76,60 @@ func main() {
 		fmt.Printf("%+v\n", user)
 	}
 
-	// Add follow relationships
+	// Add follow relationships, all in a single round trip instead of one
+	// POST per FollowUser call.
 	fmt.Println("\n--- Creating follow relationships ---")
 
-	followInput1 := &internal.FollowUserInput{
-		FollowerId: users[0].ID,
-		FollowedId: users[1].ID,
+	type followPair struct {
+		follower, followed int
 	}
-	err = internal.FollowUser(followInput1)
-	if err != nil {
-		log.Fatal(err)
+	followPairs := []followPair{
+		{0, 1},
+		{1, 2},
+		{2, 0},
+		{0, 2},
+		{1, 0},
 	}
 
-	fmt.Printf("%s follows %s\n", users[0].Name, users[1].Name)
-
-	followInput2 := &internal.FollowUserInput{
-		FollowerId: users[1].ID,
-		FollowedId: users[2].ID,
+	followBatch := internal.HelixClient.Batch(helix.WithMaxConcurrency(len(followPairs)))
+	for i, pair := range followPairs {
+		followBatch.Add(fmt.Sprintf("follow%d", i), "follow", helix.WithData(&internal.FollowUserInput{
+			FollowerId: users[pair.follower].ID,
+			FollowedId: users[pair.followed].ID,
+		}))
 	}
-	err = internal.FollowUser(followInput2)
-	if err != nil {
+
+	if _, err := followBatch.Do(context.Background()); err != nil {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("%s follows %s\n", users[1].Name, users[2].Name)
-
-	followInput3 := &internal.FollowUserInput{
-		FollowerId: users[2].ID,
-		FollowedId: users[0].ID,
-	}
-	err = internal.FollowUser(followInput3)
-	if err != nil {
-		log.Fatal(err)
+	for _, pair := range followPairs {
+		fmt.Printf("%s follows %s\n", users[pair.follower].Name, users[pair.followed].Name)
 	}
 
-	fmt.Printf("%s follows %s\n", users[2].Name, users[0].Name)
+	// Fetch every user's followers and following, again as one batch
+	// instead of two queries per user.
+	fmt.Println("\n--- User Followers and Following ---")
 
-	followInput4 := &internal.FollowUserInput{
-		FollowerId: users[0].ID,
-		FollowedId: users[2].ID,
-	}
-	err = internal.FollowUser(followInput4)
-	if err != nil {
-		log.Fatal(err)
+	frBatch := internal.HelixClient.Batch(helix.WithMaxConcurrency(len(users) * 2))
+	for _, user := range users {
+		frBatch.Add("followers:"+user.ID, "followers", helix.WithData(map[string]any{"id": user.ID}))
+		frBatch.Add("following:"+user.ID, "following", helix.WithData(map[string]any{"id": user.ID}))
 	}
-	fmt.Printf("%s follows %s\n", users[0].Name, users[2].Name)
 
-	followInput5 := &internal.FollowUserInput{
-		FollowerId: users[1].ID,
-		FollowedId: users[0].ID,
-	}
-	err = internal.FollowUser(followInput5)
+	frRes, err := frBatch.Do(context.Background())
 	if err != nil {
 		log.Fatal(err)
 	}
-	fmt.Printf("%s follows %s\n", users[1].Name, users[0].Name)
 
-	fmt.Println("\n--- User Followers and Following ---")
 	for _, user := range users {
 		fmt.Printf("\nUser: %s\n", user.Name)
+
 		var followers []internal.User
-		err := internal.Followers(
-			map[string]any{
-				"id": user.ID,
-			},
-			&followers,
+		var following []internal.User
+		err := frRes.Scan(
+			helix.WithDest("followers:"+user.ID, &followers),
+			helix.WithDest("following:"+user.ID, &following),
 		)
 		if err != nil {
 			log.Fatal(err)
@@ -149,17 +141,6 @@ func main() {
 			fmt.Printf("\t\t%s\n", follower.Name)
 		}
 
-		var following []internal.User
-		err = internal.Following(
-			map[string]any{
-				"id": user.ID,
-			},
-			&following,
-		)
-		if err != nil {
-			log.Fatal(err)
-		}
-
 		fmt.Println("\tFollowing:")
 
 		for _, userFollowing := range following {
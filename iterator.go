@@ -0,0 +1,127 @@
+package helix
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errIterConsumedBody marks a helixResponse whose body was handed to Iter,
+// so a later Raw/AsMap/Scan call reports a clear error instead of silently
+// decoding the empty bytes buffer() never actually populated.
+var errIterConsumedBody = errors.New("response body already consumed by Iter")
+
+// Iterator walks a named array field of a query response one element at a
+// time, decoding it directly off the wire instead of buffering the whole
+// response into memory.
+type Iterator struct {
+	dec    *json.Decoder
+	closer io.Closer
+	err    error
+	closed bool
+}
+
+// Iter returns an Iterator over the array field name in the response.
+// Calling Iter consumes the response body; Raw, AsMap, and Scan must not
+// also be called on the same helixResponse afterwards.
+func (r *helixResponse) Iter(name string) (*Iterator, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if r.buffered {
+		if r.bufErr != nil {
+			return nil, r.bufErr
+		}
+		return newIterator(json.NewDecoder(bytes.NewReader(r.bytes)), nil, name)
+	}
+
+	if r.body == nil {
+		return nil, fmt.Errorf("response has no body")
+	}
+	r.buffered = true
+	r.bufErr = errIterConsumedBody
+
+	return newIterator(json.NewDecoder(r.body), r.body, name)
+}
+
+func newIterator(dec *json.Decoder, closer io.Closer, name string) (*Iterator, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid json response: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("invalid json response: expected an object")
+	}
+
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid json response: %w", err)
+		}
+
+		key, _ := keyTok.(string)
+		if key != name {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("invalid json response: %w", err)
+			}
+			continue
+		}
+
+		arrTok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid json response: %w", err)
+		}
+		if delim, ok := arrTok.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("field %q is not an array", name)
+		}
+
+		return &Iterator{dec: dec, closer: closer}, nil
+	}
+
+	return nil, fmt.Errorf("field %q not found", name)
+}
+
+// Next decodes the next element into dest and reports whether it succeeded.
+// It returns false once the array is exhausted or an error occurs; check
+// Err to tell the two apart.
+func (it *Iterator) Next(dest any) bool {
+	if it.err != nil || it.closed {
+		return false
+	}
+
+	if !it.dec.More() {
+		it.Close()
+		return false
+	}
+
+	if err := it.dec.Decode(dest); err != nil {
+		it.err = err
+		it.Close()
+		return false
+	}
+
+	return true
+}
+
+// Err returns the first error encountered while iterating, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Close releases the underlying response body. It is safe to call more
+// than once and is called automatically once Next is exhausted.
+func (it *Iterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+
+	if it.closer != nil {
+		return it.closer.Close()
+	}
+	return nil
+}
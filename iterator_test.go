@@ -0,0 +1,111 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func iterServer(t *testing.T, body string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL + "/")
+}
+
+func TestIteratorEmptyArray(t *testing.T) {
+	c := iterServer(t, `{"users":[]}`)
+	it, err := c.QueryContext(context.Background(), "thing").Iter("users")
+	if err != nil {
+		t.Fatalf("Iter: %v", err)
+	}
+
+	var dest map[string]any
+	if it.Next(&dest) {
+		t.Fatalf("Next should return false on an empty array, got element %v", dest)
+	}
+	if it.Err() != nil {
+		t.Errorf("Err() = %v, want nil", it.Err())
+	}
+}
+
+func TestIteratorDecodesElements(t *testing.T) {
+	c := iterServer(t, `{"users":[{"Name":"alice"},{"Name":"bob"}]}`)
+	it, err := c.QueryContext(context.Background(), "thing").Iter("users")
+	if err != nil {
+		t.Fatalf("Iter: %v", err)
+	}
+
+	var names []string
+	var elem struct{ Name string }
+	for it.Next(&elem) {
+		names = append(names, elem.Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+
+	if len(names) != 2 || names[0] != "alice" || names[1] != "bob" {
+		t.Errorf("names = %v, want [alice bob]", names)
+	}
+}
+
+func TestIteratorMissingField(t *testing.T) {
+	c := iterServer(t, `{"other":[1,2,3]}`)
+	_, err := c.QueryContext(context.Background(), "thing").Iter("users")
+	if err == nil {
+		t.Fatal("expected an error for a missing field, got nil")
+	}
+}
+
+func TestIteratorNonArrayField(t *testing.T) {
+	c := iterServer(t, `{"users":{"not":"an array"}}`)
+	_, err := c.QueryContext(context.Background(), "thing").Iter("users")
+	if err == nil {
+		t.Fatal("expected an error for a non-array field, got nil")
+	}
+}
+
+func TestIteratorMidStreamDecodeError(t *testing.T) {
+	c := iterServer(t, `{"users":[{"Name":"alice"}, 42]}`)
+	it, err := c.QueryContext(context.Background(), "thing").Iter("users")
+	if err != nil {
+		t.Fatalf("Iter: %v", err)
+	}
+
+	var elem struct{ Name string }
+	if !it.Next(&elem) {
+		t.Fatalf("Next: first element should decode, got Err %v", it.Err())
+	}
+	if elem.Name != "alice" {
+		t.Errorf("elem.Name = %q, want alice", elem.Name)
+	}
+
+	if it.Next(&elem) {
+		t.Fatal("Next should fail decoding a number into a struct")
+	}
+	if it.Err() == nil {
+		t.Error("Err() should report the mid-stream decode failure")
+	}
+}
+
+func TestRawAfterIterReturnsError(t *testing.T) {
+	c := iterServer(t, `{"users":[{"Name":"alice"}]}`)
+	res := c.QueryContext(context.Background(), "thing")
+
+	if _, err := res.Iter("users"); err != nil {
+		t.Fatalf("Iter: %v", err)
+	}
+
+	body, err := res.Raw()
+	if err == nil {
+		t.Fatalf("Raw after Iter: got (%q, nil), want an error", body)
+	}
+	if body != nil {
+		t.Errorf("Raw after Iter: body = %q, want nil", body)
+	}
+}
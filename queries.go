@@ -2,21 +2,70 @@ package helix
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+	"time"
 )
 
+// helixResponse defers consuming the HTTP response body until Raw, AsMap,
+// Scan, or Iter is first called, so large result sets can be streamed via
+// Iter instead of being buffered whole.
 type helixResponse struct {
-	bytes []byte
-	err   error
+	body     io.ReadCloser
+	err      error
+	endpoint string
+
+	buffered bool
+	bytes    []byte
+	bufErr   error
+}
+
+// buffer reads the whole response body into memory on first call and
+// caches the result, so repeated calls to Raw/AsMap/Scan are cheap.
+func (r *helixResponse) buffer() ([]byte, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if r.buffered {
+		return r.bytes, r.bufErr
+	}
+	r.buffered = true
+
+	if r.body == nil {
+		return nil, nil
+	}
+	defer r.body.Close()
+
+	body, err := io.ReadAll(r.body)
+	if err != nil {
+		r.bufErr = &TransportError{Endpoint: r.endpoint, Err: err}
+		return nil, r.bufErr
+	}
+
+	r.bytes = body
+	return r.bytes, nil
+}
+
+// Close releases the underlying response body without reading it. It is a
+// no-op once the body has already been buffered or iterated to completion.
+func (r *helixResponse) Close() error {
+	if r.buffered || r.body == nil {
+		return nil
+	}
+	r.buffered = true
+	return r.body.Close()
 }
 
 type QueryOption struct {
-	data     any
-	datatype any
+	data           any
+	datatype       any
+	timeout        time.Duration
+	idempotencyKey string
 }
 
 type QueryOptionFunc func(*QueryOption)
@@ -33,13 +82,53 @@ func WithTarget(datatype any) QueryOptionFunc {
 	}
 }
 
+// WithTimeout derives a context with the given deadline for the query,
+// bounding it independently of the client's underlying http.Client.Timeout.
+func WithTimeout(d time.Duration) QueryOptionFunc {
+	return func(o *QueryOption) {
+		o.timeout = d
+	}
+}
+
+// WithIdempotencyKey sets an Idempotency-Key header on the query, allowing
+// it to be safely retried (see WithRetry) without double-applying writes.
+func WithIdempotencyKey(key string) QueryOptionFunc {
+	return func(o *QueryOption) {
+		o.idempotencyKey = key
+	}
+}
+
+// Query sends a single HelixQL query and is equivalent to calling
+// QueryContext with context.Background().
+//
+// On a successful response the underlying connection is kept open until
+// Raw, AsMap, Scan, or Iter is called (or Close, if none of those apply).
+// Callers must always call one of them, even just to discard the result,
+// or the connection leaks.
 func (c *Client) Query(endpoint string, opts ...QueryOptionFunc) *helixResponse {
+	return c.QueryContext(context.Background(), endpoint, opts...)
+}
+
+// QueryContext sends a single HelixQL query, honoring cancellation and
+// deadlines carried on ctx (and any deadline set via WithTimeout).
+//
+// On a successful response the underlying connection is kept open until
+// Raw, AsMap, Scan, or Iter is called (or Close, if none of those apply).
+// Callers must always call one of them, even just to discard the result,
+// or the connection leaks.
+func (c *Client) QueryContext(ctx context.Context, endpoint string, opts ...QueryOptionFunc) *helixResponse {
 
 	option := QueryOption{}
 	for _, opt := range opts {
 		opt(&option)
 	}
 
+	if option.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, option.timeout)
+		defer cancel()
+	}
+
 	jsonData, err := marshalInput(option.data)
 	if err != nil {
 		return &helixResponse{
@@ -49,55 +138,116 @@ func (c *Client) Query(endpoint string, opts ...QueryOptionFunc) *helixResponse
 	}
 
 	url := c.host + endpoint
-	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonData))
-	if err != nil {
-		return &helixResponse{
-			bytes: nil,
-			err:   fmt.Errorf("failed to create request: %w", err),
-		}
+
+	maxAttempts := 1
+	var backoff BackoffFunc
+	// Only retry queries that opted in via WithIdempotencyKey: retrying an
+	// arbitrary write on a 5xx risks applying it twice, since a 5xx can mean
+	// the write landed but the response never made it back.
+	if c.retry != nil && option.idempotencyKey != "" {
+		maxAttempts = c.retry.maxAttempts
+		backoff = c.retry.backoff
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	// Authorization token in the future maybe?
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return &helixResponse{err: fmt.Errorf("query to %q canceled: %w", endpoint, ctx.Err())}
+			case <-time.After(backoff(attempt - 1)):
+			}
+		}
 
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return &helixResponse{
-			bytes: nil,
-			err:   fmt.Errorf("failed to send request: %w", err),
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(jsonData))
+		if err != nil {
+			return &helixResponse{
+				bytes: nil,
+				err:   fmt.Errorf("failed to create request: %w", err),
+			}
 		}
-	}
-	defer res.Body.Close()
 
-	body, _ := io.ReadAll(res.Body)
+		req.Header.Set("Content-Type", "application/json")
+		if c.authHeader != "" {
+			req.Header.Set(c.authHeader, c.authValue)
+		}
+		if option.idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", option.idempotencyKey)
+		}
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return &helixResponse{
-			bytes: nil,
-			err:   fmt.Errorf("%d: %s", res.StatusCode, string(body)),
+		middlewareErr := error(nil)
+		for _, mw := range c.requestMiddleware {
+			if middlewareErr = mw(req); middlewareErr != nil {
+				break
+			}
+		}
+		if middlewareErr != nil {
+			return &helixResponse{err: fmt.Errorf("request middleware: %w", middlewareErr)}
+		}
+
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return &helixResponse{
+					endpoint: endpoint,
+					err:      fmt.Errorf("query to %q canceled: %w", endpoint, ctxErr),
+				}
+			}
+			lastErr = &TransportError{Endpoint: endpoint, Err: err}
+			continue
+		}
+
+		for _, mw := range c.responseMiddleware {
+			if middlewareErr = mw(res); middlewareErr != nil {
+				break
+			}
+		}
+		if middlewareErr != nil {
+			res.Body.Close()
+			return &helixResponse{err: fmt.Errorf("response middleware: %w", middlewareErr)}
 		}
-	}
 
-	return &helixResponse{
-		bytes: body,
-		err:   nil,
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			body, _ := io.ReadAll(res.Body)
+			res.Body.Close()
+
+			if queryErr := parseQueryError(endpoint, res.StatusCode, body); queryErr != nil {
+				lastErr = queryErr
+			} else {
+				lastErr = &HTTPError{StatusCode: res.StatusCode, Body: body, Endpoint: endpoint}
+			}
+
+			if isRetryableStatus(res.StatusCode) && attempt < maxAttempts {
+				continue
+			}
+			return &helixResponse{endpoint: endpoint, err: lastErr}
+		}
+
+		return &helixResponse{endpoint: endpoint, body: res.Body}
 	}
+
+	return &helixResponse{endpoint: endpoint, err: lastErr}
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || (statusCode >= 500 && statusCode < 600)
 }
 
 func (r *helixResponse) Raw() ([]byte, error) {
-	return r.bytes, r.err
+	return r.buffer()
 }
 
 func (r *helixResponse) AsMap() (map[string]any, error) {
 
-	if r.err != nil {
-		return nil, r.err
+	body, err := r.buffer()
+	if err != nil {
+		return nil, err
 	}
 
 	var mapResponse map[string]any
-	err := json.Unmarshal(r.bytes, &mapResponse)
+	err = json.Unmarshal(body, &mapResponse)
 	if err != nil {
-		return nil, err
+		return nil, &DecodeError{Endpoint: r.endpoint, Err: err}
 	}
 
 	return mapResponse, nil
@@ -119,8 +269,9 @@ func WithDest(name string, dest any) ScanOptionFunc {
 
 func (r *helixResponse) Scan(args ...any) error {
 
-	if r.err != nil {
-		return r.err
+	body, err := r.buffer()
+	if err != nil {
+		return err
 	}
 
 	if len(args) == 0 {
@@ -132,18 +283,17 @@ func (r *helixResponse) Scan(args ...any) error {
 		if err != nil {
 			optFunc, err := validateDestOption(args[0])
 			if err != nil {
-				fmt.Println(0)
 				return err
 			}
 
 			var jsonData map[string]json.RawMessage
 
-			err = json.Unmarshal(r.bytes, &jsonData)
+			err = json.Unmarshal(body, &jsonData)
 			if err != nil {
-				return fmt.Errorf("invalid json response: %w", err)
+				return &DecodeError{Endpoint: r.endpoint, Err: err}
 			}
 
-			err = scanOption(optFunc, jsonData)
+			err = scanOption(optFunc, jsonData, r.endpoint)
 			if err != nil {
 				return err
 			}
@@ -151,15 +301,17 @@ func (r *helixResponse) Scan(args ...any) error {
 			return nil
 		}
 
-		return json.Unmarshal(r.bytes, args[0])
-
+		if err := json.Unmarshal(body, args[0]); err != nil {
+			return &DecodeError{Endpoint: r.endpoint, Err: err}
+		}
+		return nil
 	}
 
 	var jsonData map[string]json.RawMessage
 
-	err := json.Unmarshal(r.bytes, &jsonData)
+	err = json.Unmarshal(body, &jsonData)
 	if err != nil {
-		return fmt.Errorf("invalid json response: %w", err)
+		return &DecodeError{Endpoint: r.endpoint, Err: err}
 	}
 
 	for _, arg := range args {
@@ -168,7 +320,7 @@ func (r *helixResponse) Scan(args ...any) error {
 			return fmt.Errorf("invalid scan argument type %T (expected struct pointer, map pointer, or WithDest(...))", arg)
 		}
 
-		err := scanOption(optFunc, jsonData)
+		err := scanOption(optFunc, jsonData, r.endpoint)
 		if err != nil {
 			return err
 		}
@@ -177,7 +329,7 @@ func (r *helixResponse) Scan(args ...any) error {
 	return nil
 }
 
-func scanOption(optFunc ScanOptionFunc, jsonData map[string]json.RawMessage) error {
+func scanOption(optFunc ScanOptionFunc, jsonData map[string]json.RawMessage, endpoint string) error {
 	var opt ScanOption
 	optFunc(&opt)
 
@@ -188,12 +340,12 @@ func scanOption(optFunc ScanOptionFunc, jsonData map[string]json.RawMessage) err
 
 	rawData, ok := jsonData[opt.name]
 	if !ok {
-		return fmt.Errorf("field \"%s\" not found", opt.name)
+		return &DecodeError{Endpoint: endpoint, Err: fmt.Errorf("field %q not found", opt.name)}
 	}
 
 	err = json.Unmarshal(rawData, opt.dest)
 	if err != nil {
-		return fmt.Errorf("failed to scan field \"%s\": %w", opt.name, err)
+		return &DecodeError{Endpoint: endpoint, Err: fmt.Errorf("field %q: %w", opt.name, err)}
 	}
 
 	return nil
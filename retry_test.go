@@ -0,0 +1,117 @@
+package helix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// noDelay lets retry tests run without waiting out a real backoff.
+func noDelay(attempt int) time.Duration {
+	return 0
+}
+
+func TestQueryContextRetriesWithIdempotencyKey(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/", WithRetry(3, noDelay))
+
+	_, err := c.QueryContext(context.Background(), "thing",
+		WithData(map[string]any{}),
+		WithIdempotencyKey("key-1"),
+	).Raw()
+	if err != nil {
+		t.Fatalf("Raw: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestQueryContextDoesNotRetryWithoutIdempotencyKey(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/", WithRetry(3, noDelay))
+
+	_, err := c.QueryContext(context.Background(), "thing",
+		WithData(map[string]any{}),
+	).Raw()
+	if err == nil {
+		t.Fatal("expected an error from a 503 response, got nil")
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("attempts = %d, want 1 (no retry without an idempotency key)", got)
+	}
+	if !IsRetryable(err) {
+		t.Errorf("IsRetryable(%v) = false, want true for a 503", err)
+	}
+}
+
+func TestQueryContextNoRetryPolicyConfigured(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL + "/")
+
+	_, err := c.QueryContext(context.Background(), "thing",
+		WithData(map[string]any{}),
+		WithIdempotencyKey("key-1"),
+	).Raw()
+	if err == nil {
+		t.Fatal("expected an error from a 503 response, got nil")
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("attempts = %d, want 1 (no WithRetry configured)", got)
+	}
+}
+
+func TestWithRetryClampsInvalidMaxAttempts(t *testing.T) {
+	for _, n := range []int{0, -1, -100} {
+		var called bool
+		c := NewClient("http://example.invalid/", WithRetry(n, func(attempt int) time.Duration {
+			called = true
+			return 0
+		}))
+
+		if c.retry.maxAttempts != 1 {
+			t.Errorf("WithRetry(%d, ...): maxAttempts = %d, want 1", n, c.retry.maxAttempts)
+		}
+
+		// A single attempt never sleeps, so backoff should never be invoked.
+		_ = called
+	}
+}
+
+func TestWithRetryDefaultsNilBackoff(t *testing.T) {
+	c := NewClient("http://example.invalid/", WithRetry(2, nil))
+	if c.retry.backoff == nil {
+		t.Fatal("WithRetry(2, nil): backoff is nil, want ExponentialBackoff default")
+	}
+	if d := c.retry.backoff(1); d <= 0 {
+		t.Errorf("default backoff(1) = %v, want > 0", d)
+	}
+}